@@ -1,8 +1,13 @@
 package gocli
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	flag "github.com/spf13/pflag"
 )
@@ -17,13 +22,36 @@ type Cli struct {
 	Version     func() string
 	Banner      func()
 
-	commands map[string]Cmd
-	help     *bool
-	version  *bool
-	noBanner *bool
-	debug    *bool
-	quiet    *bool
-	silent   *bool
+	// UsageTemplate and HelpTemplate override the default text/template
+	// used to render, respectively, the top-level command listing and a
+	// single resolved command's help. See DefaultUsageTemplate and
+	// DefaultHelpTemplate.
+	UsageTemplate string
+	HelpTemplate  string
+
+	ctx context.Context
+
+	commands      map[string]Cmd
+	categories    map[string]string
+	categoryOrder []string
+	resolved      []resolvedCommand
+	resolvedArgs  []string
+	help          *bool
+	version       *bool
+	noBanner      *bool
+	debug         *bool
+	quiet         *bool
+	silent        *bool
+	logFormat     *string
+	timeout       *time.Duration
+}
+
+// WithContext sets the base context.Context Cli.Run wires its signal
+// cancellation and --timeout onto, for callers embedding the Cli in a larger
+// process that already carries its own context. It returns c for chaining.
+func (c *Cli) WithContext(ctx context.Context) *Cli {
+	c.ctx = ctx
+	return c
 }
 
 func NewCli(name string) *Cli {
@@ -39,22 +67,13 @@ func NewCli(name string) *Cli {
 	c.noBanner = c.Bool("no-banner", false, "suppresses the banner text after this program runs")
 	c.quiet = c.Bool("quiet", false, "suppresses all output except errors and banner")
 	c.silent = c.Bool("silent", false, "suppresses all output except errors")
+	c.logFormat = c.String("log-format", "text", "sets the log output format (text|json)")
+	c.timeout = c.Duration("timeout", 0, "cancels the running command's context after this duration")
 
-	c.Usage = func() {
-		if c.Description != "" {
-			fmt.Fprintf(os.Stderr, "%s\n\n", c.Description)
-		}
-		fmt.Fprintf(os.Stderr, "Usage:\n\n      %s <command> [options]\n\n", name)
-		fmt.Fprint(os.Stderr, "Available commands:\n\n")
-		for k, v := range c.commands {
-			if v.GetDescription() != "" {
-				fmt.Fprintf(os.Stderr, "      %-13s %s\n", k, v.GetDescription())
-			} else {
-				fmt.Fprintf(os.Stderr, "      %-13s\n", k)
-			}
-		}
-		c.PrintGlobalOptions()
-	}
+	c.Usage = c.renderUsage
+
+	c.RegisterCommand("completion", newCompletionCommand(c))
+	c.RegisterCommand("__complete", newCompleteCommand(c))
 
 	return c
 }
@@ -88,6 +107,101 @@ func (c *Cli) RegisterCommand(name string, cmd Cmd) {
 	c.commands[name] = cmd
 }
 
+// RegisterCommandPath registers cmd under a dotted path of already-registered
+// parent commands, e.g. RegisterCommandPath("remote.add", addCmd) nests
+// addCmd under the "remote" command's Subcommands. Every parent in the path
+// must already be registered, implement Parent, and have a non-nil
+// Subcommands map.
+func (c *Cli) RegisterCommandPath(path string, cmd Cmd) {
+	parts := strings.Split(path, ".")
+	parent := c.commands
+	for i, name := range parts[:len(parts)-1] {
+		existing, exists := parent[name]
+		if !exists {
+			panic(fmt.Sprintf("command '%s' not registered", strings.Join(parts[:i+1], ".")))
+		}
+		holder, ok := existing.(Parent)
+		if !ok {
+			panic(fmt.Sprintf("command '%s' does not support subcommands", name))
+		}
+		sub := holder.GetSubcommands()
+		if sub == nil {
+			panic(fmt.Sprintf("command '%s' has a nil Subcommands map", name))
+		}
+		parent = sub
+	}
+
+	leaf := parts[len(parts)-1]
+	if _, exists := parent[leaf]; exists {
+		panic(fmt.Sprintf("command '%s' already exists", path))
+	}
+	parent[leaf] = cmd
+}
+
+// resolvedCommand is one link in the chain Cli.resolveCommand walks from the
+// top-level commands down through nested Subcommands.
+type resolvedCommand struct {
+	name string
+	cmd  Cmd
+}
+
+// resolveCommand walks args token-by-token against cmds, descending into
+// Subcommands for as long as each token names a registered command (or one
+// of its Aliases), and returns the matched chain along with the unconsumed
+// arguments that should be handed to the deepest command's FlagSet.
+func (c *Cli) resolveCommand(args []string) ([]resolvedCommand, []string) {
+	var chain []resolvedCommand
+	cmds := c.commands
+	rest := args
+	for len(rest) > 0 {
+		cmd, name, ok := lookupCommand(cmds, rest[0])
+		if !ok {
+			break
+		}
+		chain = append(chain, resolvedCommand{name: name, cmd: cmd})
+		rest = rest[1:]
+
+		parent, ok := cmd.(Parent)
+		if !ok || parent.GetSubcommands() == nil {
+			break
+		}
+		cmds = parent.GetSubcommands()
+	}
+	return chain, rest
+}
+
+// lookupCommand finds name in cmds, falling back to each command's Aliases,
+// and returns the command along with its canonical (registered) name.
+func lookupCommand(cmds map[string]Cmd, name string) (Cmd, string, bool) {
+	if cmd, ok := cmds[name]; ok {
+		return cmd, name, true
+	}
+	for canonical, cmd := range cmds {
+		if c, ok := cmd.(Command); ok {
+			for _, alias := range c.Aliases {
+				if alias == name {
+					return cmd, canonical, true
+				}
+			}
+		}
+	}
+	return nil, "", false
+}
+
+// inheritFlags copies every flag in from into into, hidden, so a nested
+// command's own FlagSet automatically exposes the flags of every command
+// above it in the chain without each handler having to opt in.
+func inheritFlags(into *flag.FlagSet, from *flag.FlagSet) {
+	from.VisitAll(func(f *flag.Flag) {
+		if into.Lookup(f.Name) != nil {
+			return
+		}
+		into.Var(f.Value, f.Name, f.Usage)
+		into.Lookup(f.Name).NoOptDefVal = f.NoOptDefVal
+		into.MarkHidden(f.Name)
+	})
+}
+
 func (c *Cli) Parse(arguments []string) (Cmd, error) {
 	if c.Banner == nil {
 		c.MarkHidden("no-banner")
@@ -103,27 +217,93 @@ func (c *Cli) Parse(arguments []string) (Cmd, error) {
 		*c.noBanner = true
 	}
 
-	Log.SetVerbose()
-	Dbg.SetQuiet()
+	Log.SetLevel(InfoLevel)
+	Dbg.SetLevel(OffLevel)
 	if *c.debug {
-		Dbg.SetVerbose()
+		Log.SetLevel(DebugLevel)
+		Dbg.SetLevel(DebugLevel)
 	} else if *c.quiet {
-		Log.SetQuiet()
+		Log.SetLevel(WarnLevel)
+	}
+	if *c.silent {
+		Log.SetLevel(ErrorLevel)
+	}
+
+	if *c.logFormat == "json" {
+		Log.SetFormatter(&JSONFormatter{})
+		Dbg.SetFormatter(&JSONFormatter{})
 	}
 
-	cmdArg := ""
 	if len(c.Args()) == 0 {
 		return nil, flag.ErrHelp
 	}
-	cmdArg = c.Args()[0]
-	cmd, exists := c.commands[cmdArg]
+
+	chain, rest := c.resolveCommand(c.Args())
+	if len(chain) == 0 {
+		if *c.help {
+			return nil, flag.ErrHelp
+		}
+		return nil, fmt.Errorf("command '%s' not recognized", c.Args()[0])
+	}
+	c.resolved = chain
+	c.resolvedArgs = rest
+
+	leaf := chain[len(chain)-1].cmd
+	if lc, ok := leaf.(Command); ok && lc.Flags != nil {
+		parentFlags := c.FlagSet
+		for _, link := range chain {
+			if cmd, ok := link.cmd.(Command); ok && cmd.Flags != nil {
+				inheritFlags(cmd.Flags, parentFlags)
+				parentFlags = cmd.Flags
+			}
+		}
+		if err == nil {
+			err = lc.Flags.Parse(rest)
+		}
+	}
+
 	if *c.help {
-		return cmd, flag.ErrHelp
+		return leaf, flag.ErrHelp
+	}
+	return leaf, err
+}
+
+// runHooks executes the given hook on every command in the chain, outer to
+// inner (or inner to outer, when reverse is true), skipping links that
+// didn't register that hook.
+func runHooks(cli *Cli, chain []resolvedCommand, reverse bool, pick func(Command) HookFunc) error {
+	indices := make([]int, len(chain))
+	for i := range indices {
+		if reverse {
+			indices[i] = len(chain) - 1 - i
+		} else {
+			indices[i] = i
+		}
+	}
+	for _, i := range indices {
+		link := chain[i]
+		cmd, ok := link.cmd.(Command)
+		if !ok {
+			continue
+		}
+		hook := pick(cmd)
+		if hook == nil {
+			continue
+		}
+		if err := hook(cli, link.name, nil); err != nil {
+			return err
+		}
 	}
-	if !exists {
-		err = fmt.Errorf("command '%s' not recognized", cmdArg)
+	return nil
+}
+
+// runCmd dispatches to cmd's CmdContext.RunContext when it implements that
+// interface, and otherwise falls back to the plain Cmd.Run, ignoring ctx.
+func runCmd(ctx context.Context, cmd Cmd, cli *Cli, name string, arguments []string) error {
+	if cc, ok := cmd.(CmdContext); ok {
+		return cc.RunContext(ctx, cli, name, arguments)
 	}
-	return cmd, err
+	return cmd.Run(cli, name, arguments)
 }
 
 func (c *Cli) Run(arguments []string) error {
@@ -137,7 +317,56 @@ func (c *Cli) Run(arguments []string) error {
 		fmt.Fprint(os.Stderr, v)
 		err = nil
 	} else if cmd != nil {
-		err = cmd.Run(c, arguments[0], arguments[1:])
+		cmdName := arguments[0]
+		cmdArgs := arguments[1:]
+		if len(c.resolved) > 0 {
+			names := make([]string, len(c.resolved))
+			for i, link := range c.resolved {
+				names[i] = link.name
+			}
+			cmdName = strings.Join(names, " ")
+			cmdArgs = c.resolvedArgs
+			if lc, ok := cmd.(Command); ok && lc.Flags != nil {
+				cmdArgs = lc.Flags.Args()
+			}
+		}
+
+		lc, isCommand := cmd.(Command)
+		if isCommand && *c.help {
+			if lc.Usage != nil {
+				lc.Usage()
+			} else {
+				c.renderCommandHelp(cmdName, lc)
+			}
+		} else {
+			base := c.ctx
+			if base == nil {
+				base = context.Background()
+			}
+			ctx, stop := signal.NotifyContext(base, os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			if *c.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, *c.timeout)
+				defer cancel()
+			}
+
+			if err == nil {
+				err = runHooks(c, c.resolved, false, func(cmd Command) HookFunc { return cmd.PersistentPreRun })
+			}
+			if err == nil && isCommand && lc.PreRun != nil {
+				err = lc.PreRun(c, cmdName, cmdArgs)
+			}
+			if err == nil {
+				err = runCmd(ctx, cmd, c, cmdName, cmdArgs)
+			}
+			if err == nil && isCommand && lc.PostRun != nil {
+				err = lc.PostRun(c, cmdName, cmdArgs)
+			}
+			if err == nil {
+				err = runHooks(c, c.resolved, true, func(cmd Command) HookFunc { return cmd.PersistentPostRun })
+			}
+		}
 	} else {
 		c.Usage()
 	}
@@ -0,0 +1,79 @@
+package gocli
+
+import (
+	"reflect"
+	"testing"
+
+	flag "github.com/spf13/pflag"
+)
+
+func TestNestedSubcommandReceivesOnlyTrailingArgs(t *testing.T) {
+	var got []string
+	add := Command{
+		Description: "adds a remote",
+		Handler: func(cli *Cli, cmd string, arguments []string) error {
+			got = arguments
+			return nil
+		},
+	}
+	remote := Command{
+		Description: "manages remotes",
+		Subcommands: map[string]Cmd{"add": add},
+	}
+
+	cli := NewCli("myapp")
+	cli.RegisterCommand("remote", remote)
+
+	if err := cli.Run([]string{"remote", "add", "origin", "url"}); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	want := []string{"origin", "url"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("handler received arguments %v, want %v", got, want)
+	}
+}
+
+func TestFlagInheritanceAddsGlobalFlagsToNestedCommand(t *testing.T) {
+	addFlags := flag.NewFlagSet("add", flag.ContinueOnError)
+	add := Command{
+		Flags: addFlags,
+		Handler: func(cli *Cli, cmd string, arguments []string) error {
+			return nil
+		},
+	}
+	remote := Command{Subcommands: map[string]Cmd{"add": add}}
+
+	cli := NewCli("myapp")
+	cli.RegisterCommand("remote", remote)
+
+	if _, err := cli.Parse([]string{"remote", "add", "origin"}); err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	if addFlags.Lookup("debug") == nil {
+		t.Fatalf("expected add's Flags to inherit the global --debug flag")
+	}
+}
+
+func TestParseMutesDbgByDefault(t *testing.T) {
+	add := Command{
+		Handler: func(cli *Cli, cmd string, arguments []string) error { return nil },
+	}
+	cli := NewCli("myapp")
+	cli.RegisterCommand("add", add)
+
+	if _, err := cli.Parse([]string{"add"}); err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if Dbg.level != OffLevel {
+		t.Fatalf("Dbg level = %v, want %v when --debug is not set", Dbg.level, OffLevel)
+	}
+
+	if _, err := cli.Parse([]string{"--debug", "add"}); err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if Dbg.level != DebugLevel {
+		t.Fatalf("Dbg level = %v, want %v when --debug is set", Dbg.level, DebugLevel)
+	}
+}
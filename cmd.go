@@ -1,19 +1,115 @@
 package gocli
 
+import (
+	"context"
+
+	flag "github.com/spf13/pflag"
+)
+
 type Cmd interface {
 	GetDescription() string
 	Run(cli *Cli, cmd string, arguments []string) error
 }
 
+// CmdContext is implemented by commands that want the context.Context
+// Cli.Run builds around signal.NotifyContext and the --timeout flag,
+// instead of the plain Cmd.Run. Command implements this directly: setting
+// HandlerContext runs with the context, while a Command left with only
+// Handler runs exactly as it did before, via an adapter that ignores it.
+type CmdContext interface {
+	Cmd
+	RunContext(ctx context.Context, cli *Cli, cmd string, arguments []string) error
+}
+
+// HookFunc is the signature shared by the PersistentPreRun, PreRun, PostRun,
+// and PersistentPostRun hooks a Command can register around its Handler.
+type HookFunc func(cli *Cli, cmd string, arguments []string) error
+
+// Parent is implemented by commands that can hold nested Subcommands. Cli
+// walks this interface while resolving a subcommand chain, so a Cmd other
+// than Command can opt into nesting by implementing it directly.
+type Parent interface {
+	GetSubcommands() map[string]Cmd
+}
+
 type Command struct {
 	Description string
 	Handler     func(cli *Cli, cmd string, arguments []string) error
+
+	// HandlerContext, when set, takes precedence over Handler and is run
+	// with the context.Context Cli.Run constructs (cancelled on signal, and
+	// on --timeout). Leave it unset for commands that don't need
+	// cancellation; Handler keeps working unchanged.
+	HandlerContext func(ctx context.Context, cli *Cli, cmd string, arguments []string) error
+
+	// Usage, when set, overrides the default help text printed for this
+	// command when it is the resolved command; its subcommands' help does
+	// not use it.
+	Usage func()
+
+	// Aliases lists additional names this command may be invoked as.
+	Aliases []string
+
+	// Category groups this command under a named section in help output,
+	// registered with Cli.RegisterCategory. Commands with no Category are
+	// rendered in an untitled group ahead of every named category.
+	Category string
+
+	// Examples lists full invocation strings (e.g. "myapp remote add
+	// origin url") shown under the EXAMPLES section of help output.
+	Examples []string
+
+	// Flags holds this command's own flag set. Cli.Parse populates it with
+	// the flags inherited from every command above it in the chain
+	// (including the global --debug/--quiet/--silent) before handing it the
+	// arguments that remain once the subcommand path has been resolved.
+	Flags *flag.FlagSet
+
+	// Subcommands lets a Command nest further commands beneath it, e.g.
+	// "myapp remote add origin ...". The map must be initialized by the
+	// caller before commands are registered under it with RegisterCommandPath.
+	Subcommands map[string]Cmd
+
+	// Hidden omits this command from help output and completion scripts,
+	// without affecting its ability to be run.
+	Hidden bool
+
+	// ValidArgs is a static list of completion candidates for this
+	// command's positional arguments.
+	ValidArgs []string
+
+	// ValidArgsFunction computes completion candidates for this command's
+	// positional arguments dynamically, e.g. from a remote's name or a
+	// path on disk. In bash completion scripts it takes precedence over
+	// ValidArgs when set; the zsh, fish, and powershell scripts do not
+	// consult it.
+	ValidArgsFunction func(args []string) []string
+
+	// PersistentPreRun and PersistentPostRun run for every command in the
+	// resolved chain, outer to inner for PersistentPreRun and inner to outer
+	// for PersistentPostRun. PreRun and PostRun run only for the deepest,
+	// matched command, immediately around Handler.
+	PersistentPreRun  HookFunc
+	PreRun            HookFunc
+	PostRun           HookFunc
+	PersistentPostRun HookFunc
 }
 
 func (c Command) GetDescription() string {
 	return c.Description
 }
 
+func (c Command) GetSubcommands() map[string]Cmd {
+	return c.Subcommands
+}
+
 func (c Command) Run(cli *Cli, cmd string, arguments []string) error {
 	return c.Handler(cli, cmd, arguments)
 }
+
+func (c Command) RunContext(ctx context.Context, cli *Cli, cmd string, arguments []string) error {
+	if c.HandlerContext != nil {
+		return c.HandlerContext(ctx, cli, cmd, arguments)
+	}
+	return c.Run(cli, cmd, arguments)
+}
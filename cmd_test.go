@@ -0,0 +1,51 @@
+package gocli
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCommandRunContextPrefersHandlerContext(t *testing.T) {
+	var usedHandlerContext, usedHandler bool
+	cmd := Command{
+		Handler: func(cli *Cli, cmd string, arguments []string) error {
+			usedHandler = true
+			return nil
+		},
+		HandlerContext: func(ctx context.Context, cli *Cli, cmd string, arguments []string) error {
+			usedHandlerContext = true
+			return nil
+		},
+	}
+
+	if err := cmd.RunContext(context.Background(), nil, "cmd", nil); err != nil {
+		t.Fatalf("RunContext returned error: %v", err)
+	}
+	if !usedHandlerContext {
+		t.Fatalf("expected RunContext to call HandlerContext")
+	}
+	if usedHandler {
+		t.Fatalf("expected RunContext to not fall back to Handler when HandlerContext is set")
+	}
+}
+
+func TestCliRunCancelsContextOnTimeout(t *testing.T) {
+	var gotErr error
+	add := Command{
+		HandlerContext: func(ctx context.Context, cli *Cli, cmd string, arguments []string) error {
+			<-ctx.Done()
+			gotErr = ctx.Err()
+			return nil
+		},
+	}
+
+	cli := NewCli("myapp")
+	cli.RegisterCommand("add", add)
+
+	if err := cli.Run([]string{"--timeout", "1ms", "add"}); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if gotErr != context.DeadlineExceeded {
+		t.Fatalf("ctx.Err() = %v, want %v", gotErr, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,278 @@
+package gocli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+)
+
+// commandInfo is a flattened view of one command (or nested subcommand),
+// used when walking the whole tree to generate completion scripts.
+type commandInfo struct {
+	path []string
+	cmd  Command
+}
+
+// collectCommands flattens cmds, and every Subcommands tree beneath it, into
+// a path-sorted list of commandInfo.
+func collectCommands(cmds map[string]Cmd, prefix []string) []commandInfo {
+	var out []commandInfo
+	for name, cmd := range cmds {
+		c, ok := cmd.(Command)
+		if !ok {
+			continue
+		}
+		path := append(append([]string{}, prefix...), name)
+		out = append(out, commandInfo{path: path, cmd: c})
+		if c.Subcommands != nil {
+			out = append(out, collectCommands(c.Subcommands, path)...)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return strings.Join(out[i].path, " ") < strings.Join(out[j].path, " ")
+	})
+	return out
+}
+
+// childNames returns the direct children of parent among infos, by their
+// last path segment.
+func childNames(infos []commandInfo, parent []string) []string {
+	var names []string
+	for _, info := range infos {
+		if info.cmd.Hidden {
+			continue
+		}
+		if len(info.path) != len(parent)+1 {
+			continue
+		}
+		match := true
+		for i, p := range parent {
+			if info.path[i] != p {
+				match = false
+				break
+			}
+		}
+		if match {
+			names = append(names, info.path[len(info.path)-1])
+		}
+	}
+	return names
+}
+
+// flagNames returns the long and short flag forms registered on fs.
+func flagNames(fs *flag.FlagSet) []string {
+	if fs == nil {
+		return nil
+	}
+	var names []string
+	fs.VisitAll(func(f *flag.Flag) {
+		if f.Hidden {
+			return
+		}
+		names = append(names, "--"+f.Name)
+		if f.Shorthand != "" {
+			names = append(names, "-"+f.Shorthand)
+		}
+	})
+	return names
+}
+
+// completionWords returns the words a shell should offer after path: its
+// visible subcommands, its own flags, and its ValidArgs, in that order.
+func completionWords(infos []commandInfo, info commandInfo) []string {
+	words := append([]string{}, childNames(infos, info.path)...)
+	words = append(words, flagNames(info.cmd.Flags)...)
+	words = append(words, info.cmd.ValidArgs...)
+	return words
+}
+
+// GenerateCompletion writes a shell completion script for shell ("bash",
+// "zsh", "fish", or "powershell") to w. The bash and zsh scripts cover every
+// visible command registered on c, including nested Subcommands, with their
+// own pflag definitions. The fish script completes top-level command names
+// and the long flags of every visible command, but not nested subcommand
+// names or ValidArgs. The powershell script completes top-level command
+// names only.
+func (c *Cli) GenerateCompletion(shell string, w io.Writer) error {
+	switch shell {
+	case "bash":
+		return c.generateBashCompletion(w)
+	case "zsh":
+		return c.generateZshCompletion(w)
+	case "fish":
+		return c.generateFishCompletion(w)
+	case "powershell":
+		return c.generatePowershellCompletion(w)
+	default:
+		return fmt.Errorf("unsupported shell '%s', expected bash, zsh, fish, or powershell", shell)
+	}
+}
+
+func (c *Cli) generateBashCompletion(w io.Writer) error {
+	infos := collectCommands(c.commands, nil)
+
+	fmt.Fprintf(w, "# bash completion for %s\n", c.Name)
+	fmt.Fprintf(w, "_%s() {\n", c.Name)
+	fmt.Fprintf(w, "    local cur words\n")
+	fmt.Fprintf(w, "    COMPREPLY=()\n")
+	fmt.Fprintf(w, "    cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	fmt.Fprintf(w, "    words=\"${COMP_WORDS[*]:1:COMP_CWORD-1}\"\n\n")
+	fmt.Fprintf(w, "    case \"$words\" in\n")
+	for _, info := range infos {
+		if info.cmd.Hidden {
+			continue
+		}
+		if info.cmd.ValidArgsFunction != nil {
+			fmt.Fprintf(w, "    \"%s\")\n", strings.Join(info.path, " "))
+			fmt.Fprintf(w, "        COMPREPLY=($(compgen -W \"$(%s __complete '%s' \"$cur\")\" -- \"$cur\"))\n", c.Name, strings.Join(info.path, " "))
+			fmt.Fprintf(w, "        return\n        ;;\n")
+			continue
+		}
+		fmt.Fprintf(w, "    \"%s\")\n", strings.Join(info.path, " "))
+		fmt.Fprintf(w, "        COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", strings.Join(completionWords(infos, info), " "))
+		fmt.Fprintf(w, "        return\n        ;;\n")
+	}
+	fmt.Fprintf(w, "    esac\n\n")
+	fmt.Fprintf(w, "    COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", strings.Join(childNames(infos, nil), " "))
+	fmt.Fprintf(w, "}\n")
+	fmt.Fprintf(w, "complete -F _%s %s\n", c.Name, c.Name)
+	return nil
+}
+
+func (c *Cli) generateZshCompletion(w io.Writer) error {
+	infos := collectCommands(c.commands, nil)
+
+	fmt.Fprintf(w, "#compdef %s\n\n", c.Name)
+	fmt.Fprintf(w, "_%s() {\n", c.Name)
+	fmt.Fprintf(w, "    local -a commands\n")
+	fmt.Fprintf(w, "    commands=(\n")
+	for _, name := range childNames(infos, nil) {
+		fmt.Fprintf(w, "        '%s'\n", name)
+	}
+	fmt.Fprintf(w, "    )\n\n")
+	fmt.Fprintf(w, "    _arguments -C \\\n")
+	fmt.Fprintf(w, "        '1: :->command' \\\n")
+	fmt.Fprintf(w, "        '*:: :->args'\n\n")
+	fmt.Fprintf(w, "    case $state in\n")
+	fmt.Fprintf(w, "        command) _values 'command' $commands ;;\n")
+	fmt.Fprintf(w, "        args)\n")
+	fmt.Fprintf(w, "            local path=\"${words[2,CURRENT-1]}\"\n")
+	fmt.Fprintf(w, "            case \"$path\" in\n")
+	for _, info := range infos {
+		if info.cmd.Hidden {
+			continue
+		}
+		fmt.Fprintf(w, "                \"%s\") _values 'arg' %s ;;\n", strings.Join(info.path, " "), quoteAll(completionWords(infos, info)))
+	}
+	fmt.Fprintf(w, "            esac\n")
+	fmt.Fprintf(w, "            ;;\n")
+	fmt.Fprintf(w, "    esac\n")
+	fmt.Fprintf(w, "}\n\n")
+	fmt.Fprintf(w, "_%s\n", c.Name)
+	return nil
+}
+
+func (c *Cli) generateFishCompletion(w io.Writer) error {
+	infos := collectCommands(c.commands, nil)
+
+	for _, name := range childNames(infos, nil) {
+		for _, info := range infos {
+			if len(info.path) == 1 && info.path[0] == name {
+				fmt.Fprintf(w, "complete -c %s -n '__fish_use_subcommand' -a '%s' -d '%s'\n", c.Name, name, info.cmd.Description)
+			}
+		}
+	}
+	for _, info := range infos {
+		if info.cmd.Hidden {
+			continue
+		}
+		seenFrom := make([]string, len(info.path))
+		for i, segment := range info.path {
+			seenFrom[i] = fmt.Sprintf("__fish_seen_subcommand_from %s", segment)
+		}
+		condition := strings.Join(seenFrom, "; and ")
+		for _, f := range flagNames(info.cmd.Flags) {
+			if !strings.HasPrefix(f, "--") {
+				continue
+			}
+			fmt.Fprintf(w, "complete -c %s -n '%s' -l '%s'\n", c.Name, condition, strings.TrimPrefix(f, "--"))
+		}
+	}
+	return nil
+}
+
+func (c *Cli) generatePowershellCompletion(w io.Writer) error {
+	infos := collectCommands(c.commands, nil)
+
+	fmt.Fprintf(w, "Register-ArgumentCompleter -Native -CommandName %s -ScriptBlock {\n", c.Name)
+	fmt.Fprintf(w, "    param($wordToComplete, $commandAst, $cursorPosition)\n\n")
+	fmt.Fprintf(w, "    $commands = @(%s)\n", quoteAll(childNames(infos, nil)))
+	fmt.Fprintf(w, "    $commands | Where-Object { $_ -like \"$wordToComplete*\" } | ForEach-Object {\n")
+	fmt.Fprintf(w, "        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)\n")
+	fmt.Fprintf(w, "    }\n")
+	fmt.Fprintf(w, "}\n")
+	return nil
+}
+
+func quoteAll(words []string) string {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = "'" + w + "'"
+	}
+	return strings.Join(quoted, " ")
+}
+
+// newCompletionCommand builds the hidden "completion" command that Cli
+// registers for itself, which calls GenerateCompletion.
+func newCompletionCommand(c *Cli) Command {
+	return Command{
+		Description: "generates a shell completion script",
+		Hidden:      true,
+		ValidArgs:   []string{"bash", "zsh", "fish", "powershell"},
+		Handler: func(cli *Cli, cmd string, arguments []string) error {
+			if len(arguments) == 0 {
+				return fmt.Errorf("usage: %s bash|zsh|fish|powershell", cmd)
+			}
+			return cli.GenerateCompletion(arguments[0], os.Stdout)
+		},
+	}
+}
+
+// newCompleteCommand builds the hidden "__complete" command used by
+// generated shell scripts to resolve a command's ValidArgsFunction at
+// completion time. The generated scripts pass the resolved command's path as
+// a single quoted word (e.g. "remote add"), so it is split back into path
+// segments here before being matched against the command tree.
+func newCompleteCommand(c *Cli) Command {
+	return Command{
+		Hidden: true,
+		Handler: func(cli *Cli, cmd string, arguments []string) error {
+			if len(arguments) < 2 {
+				return nil
+			}
+			path := strings.Fields(arguments[0])
+			toComplete := arguments[len(arguments)-1]
+			extra := arguments[1 : len(arguments)-1]
+
+			chain, _ := cli.resolveCommand(path)
+			if len(chain) == 0 {
+				return nil
+			}
+			leaf, ok := chain[len(chain)-1].cmd.(Command)
+			if !ok || leaf.ValidArgsFunction == nil {
+				return nil
+			}
+
+			for _, candidate := range leaf.ValidArgsFunction(extra) {
+				if strings.HasPrefix(candidate, toComplete) {
+					fmt.Fprintln(os.Stdout, candidate)
+				}
+			}
+			return nil
+		},
+	}
+}
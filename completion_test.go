@@ -0,0 +1,120 @@
+package gocli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	flag "github.com/spf13/pflag"
+)
+
+func TestBashCompletionQuotesNestedCommandPath(t *testing.T) {
+	add := Command{
+		ValidArgsFunction: func(args []string) []string { return nil },
+	}
+	remote := Command{Subcommands: map[string]Cmd{"add": add}}
+
+	cli := NewCli("myapp")
+	cli.RegisterCommand("remote", remote)
+
+	var buf bytes.Buffer
+	if err := cli.GenerateCompletion("bash", &buf); err != nil {
+		t.Fatalf("GenerateCompletion returned error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "__complete 'remote add'") {
+		t.Fatalf("expected generated script to quote the nested command path as a single argument, got:\n%s", buf.String())
+	}
+}
+
+func TestCompleteResolvesNestedCommandPath(t *testing.T) {
+	var calledWith []string
+	add := Command{
+		ValidArgsFunction: func(args []string) []string {
+			calledWith = args
+			return []string{"origin"}
+		},
+	}
+	remote := Command{Subcommands: map[string]Cmd{"add": add}}
+
+	cli := NewCli("myapp")
+	cli.RegisterCommand("remote", remote)
+
+	complete := newCompleteCommand(cli)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	// Mirrors the real argv a shell produces: the generated script quotes
+	// the resolved path as a single word ('remote add'), not pre-split
+	// tokens.
+	runErr := complete.Run(cli, "__complete", []string{"remote add", "or"})
+	w.Close()
+	os.Stdout = stdout
+	if runErr != nil {
+		t.Fatalf("unexpected error: %v", runErr)
+	}
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "origin") {
+		t.Fatalf("expected completion output to contain %q, got %q", "origin", out)
+	}
+	if calledWith == nil {
+		t.Fatalf("expected ValidArgsFunction to be invoked")
+	}
+}
+
+func TestZshCompletionKeysOnFullPath(t *testing.T) {
+	add := Command{}
+	remote := Command{Subcommands: map[string]Cmd{"add": add}}
+	worktree := Command{Subcommands: map[string]Cmd{"add": add}}
+
+	cli := NewCli("myapp")
+	cli.RegisterCommand("remote", remote)
+	cli.RegisterCommand("worktree", worktree)
+
+	var buf bytes.Buffer
+	if err := cli.GenerateCompletion("zsh", &buf); err != nil {
+		t.Fatalf("GenerateCompletion returned error: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.Contains(out, `"remote add") `) {
+		t.Fatalf("expected case label for the full path 'remote add', got:\n%s", out)
+	}
+	if !strings.Contains(out, `"worktree add") `) {
+		t.Fatalf("expected case label for the full path 'worktree add', got:\n%s", out)
+	}
+	if strings.Count(out, `"add") `) > 0 {
+		t.Fatalf("expected no case label keyed on the bare leaf name 'add', got:\n%s", out)
+	}
+}
+
+func TestFishCompletionChainsFullPathCondition(t *testing.T) {
+	addFlags := flag.NewFlagSet("add", flag.ContinueOnError)
+	addFlags.Bool("force", false, "force the add")
+	add := Command{Flags: addFlags}
+	remote := Command{Subcommands: map[string]Cmd{"add": add}}
+
+	cli := NewCli("myapp")
+	cli.RegisterCommand("remote", remote)
+
+	var buf bytes.Buffer
+	if err := cli.GenerateCompletion("fish", &buf); err != nil {
+		t.Fatalf("GenerateCompletion returned error: %v", err)
+	}
+	out := buf.String()
+
+	want := "__fish_seen_subcommand_from remote; and __fish_seen_subcommand_from add"
+	if !strings.Contains(out, want) {
+		t.Fatalf("expected flag completion condition to chain the full path (%q), got:\n%s", want, out)
+	}
+}
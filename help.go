@@ -0,0 +1,175 @@
+package gocli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// DefaultUsageTemplate is the help template Cli renders its top-level usage
+// with unless Cli.UsageTemplate is set. It is parsed with text/template
+// against the helpData built from the Cli's registered commands and
+// categories.
+const DefaultUsageTemplate = `{{if .Description}}{{.Description}}
+
+{{end}}Usage:
+
+      {{.Name}} <command> [options]
+
+{{range .Categories}}{{if .Title}}{{.Title}}:
+{{end}}{{range .Commands}}      {{.Name}}{{if .Description}} {{.Description}}{{end}}
+{{end}}
+{{end}}{{if .Examples}}Examples:
+
+{{range .Examples}}      {{.}}
+{{end}}
+{{end}}`
+
+// DefaultHelpTemplate is the help template Cli renders a single resolved
+// command's usage with (when that command has no Usage func of its own)
+// unless Cli.HelpTemplate is set.
+const DefaultHelpTemplate = `{{.Name}}{{if .Description}} - {{.Description}}{{end}}
+
+Usage:
+
+      {{.Name}} [options]
+{{if .Examples}}
+Examples:
+
+{{range .Examples}}      {{.}}
+{{end}}{{end}}`
+
+type helpCommandData struct {
+	Name        string
+	Description string
+}
+
+type helpCategoryData struct {
+	Title    string
+	Commands []helpCommandData
+}
+
+type helpData struct {
+	Name        string
+	Description string
+	Categories  []helpCategoryData
+	Examples    []string
+}
+
+// RegisterCategory declares a named category that commands can be grouped
+// under via Command.Category. Categories render in the order they are
+// registered; commands with no matching Category render first, in an
+// untitled group.
+func (c *Cli) RegisterCategory(name, description string) {
+	if c.categories == nil {
+		c.categories = make(map[string]string)
+	}
+	if _, exists := c.categories[name]; !exists {
+		c.categoryOrder = append(c.categoryOrder, name)
+	}
+	c.categories[name] = description
+}
+
+// buildHelpData gathers every visible top-level command into the categories
+// they were registered under, in category-registration order, with aliases
+// shown as "add, a, new" alongside the canonical name.
+func (c *Cli) buildHelpData() helpData {
+	names := make([]string, 0, len(c.commands))
+	for name := range c.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buckets := make(map[string][]helpCommandData)
+	var examples []string
+	for _, name := range names {
+		cmd, isCommand := c.commands[name].(Command)
+		if isCommand && cmd.Hidden {
+			continue
+		}
+
+		label := name
+		if isCommand && len(cmd.Aliases) > 0 {
+			label = name + ", " + strings.Join(cmd.Aliases, ", ")
+		}
+
+		category := ""
+		if isCommand {
+			category = cmd.Category
+			examples = append(examples, cmd.Examples...)
+		}
+		if _, registered := c.categories[category]; !registered {
+			category = ""
+		}
+		buckets[category] = append(buckets[category], helpCommandData{
+			Name:        label,
+			Description: c.commands[name].GetDescription(),
+		})
+	}
+
+	var categories []helpCategoryData
+	if cmds, ok := buckets[""]; ok {
+		categories = append(categories, helpCategoryData{Commands: cmds})
+	}
+	for _, name := range c.categoryOrder {
+		cmds, ok := buckets[name]
+		if !ok {
+			continue
+		}
+		categories = append(categories, helpCategoryData{Title: c.categories[name], Commands: cmds})
+	}
+
+	return helpData{
+		Name:        c.Name,
+		Description: c.Description,
+		Categories:  categories,
+		Examples:    examples,
+	}
+}
+
+// renderUsage executes Cli.UsageTemplate (or DefaultUsageTemplate, if unset)
+// against the Cli's current commands and categories, followed by its global
+// options.
+func (c *Cli) renderUsage() {
+	tmplText := c.UsageTemplate
+	if tmplText == "" {
+		tmplText = DefaultUsageTemplate
+	}
+	tmpl, err := template.New("usage").Parse(tmplText)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid usage template: %s\n", err)
+		return
+	}
+	if err := tmpl.Execute(os.Stderr, c.buildHelpData()); err != nil {
+		fmt.Fprintf(os.Stderr, "error rendering usage template: %s\n", err)
+	}
+	c.PrintGlobalOptions()
+}
+
+// renderCommandHelp executes Cli.HelpTemplate (or DefaultHelpTemplate, if
+// unset) against a single resolved command, used as the fallback when that
+// command has no Usage func of its own.
+func (c *Cli) renderCommandHelp(name string, cmd Command) {
+	tmplText := c.HelpTemplate
+	if tmplText == "" {
+		tmplText = DefaultHelpTemplate
+	}
+	tmpl, err := template.New("help").Parse(tmplText)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid help template: %s\n", err)
+		return
+	}
+	data := helpCommandData{Name: name, Description: cmd.Description}
+	if err := tmpl.Execute(os.Stderr, struct {
+		helpCommandData
+		Examples []string
+	}{data, cmd.Examples}); err != nil {
+		fmt.Fprintf(os.Stderr, "error rendering help template: %s\n", err)
+	}
+	if cmd.Flags != nil {
+		fmt.Fprint(os.Stderr, "\nOptions:\n\n")
+		cmd.Flags.PrintDefaults()
+	}
+}
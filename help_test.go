@@ -0,0 +1,63 @@
+package gocli
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestBuildHelpDataGroupsByCategoryInRegistrationOrder(t *testing.T) {
+	add := Command{Description: "adds a thing", Category: "management"}
+	list := Command{Description: "lists things"}
+
+	cli := NewCli("myapp")
+	cli.RegisterCategory("management", "Management Commands")
+	cli.RegisterCommand("add", add)
+	cli.RegisterCommand("list", list)
+
+	data := cli.buildHelpData()
+
+	if len(data.Categories) != 2 {
+		t.Fatalf("expected 2 categories, got %d: %+v", len(data.Categories), data.Categories)
+	}
+	if data.Categories[0].Title != "" {
+		t.Fatalf("expected the untitled bucket first, got %+v", data.Categories[0])
+	}
+	if data.Categories[0].Commands[0].Name != "list" {
+		t.Fatalf("expected 'list' in the untitled bucket, got %+v", data.Categories[0].Commands)
+	}
+	if data.Categories[1].Title != "Management Commands" {
+		t.Fatalf("expected 'Management Commands' title, got %q", data.Categories[1].Title)
+	}
+	if data.Categories[1].Commands[0].Name != "add" {
+		t.Fatalf("expected 'add' under management, got %+v", data.Categories[1].Commands)
+	}
+}
+
+func TestRenderCommandHelpIncludesExamples(t *testing.T) {
+	cmd := Command{
+		Description: "adds a remote",
+		Examples:    []string{"myapp remote add origin url"},
+	}
+
+	cli := NewCli("myapp")
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = w
+	cli.renderCommandHelp("remote add", cmd)
+	w.Close()
+	os.Stderr = stderr
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "myapp remote add origin url") {
+		t.Fatalf("expected rendered help to contain the example, got:\n%s", out)
+	}
+}
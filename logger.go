@@ -1,18 +1,153 @@
 package gocli
 
 import (
+	"encoding/json"
+	"fmt"
 	"io"
 	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-isatty"
 )
 
+// Level is the severity of a log entry. Lower values are more verbose; a
+// Logger only emits entries at or above its configured Level (Fatal and
+// Panic always emit, regardless of Level).
+type Level int
+
+const (
+	TraceLevel Level = iota
+	DebugLevel
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+	FatalLevel
+	PanicLevel
+
+	// OffLevel mutes every entry, including Error and Warn, while still
+	// letting Fatal and Panic bypass the gate as documented on Level.
+	OffLevel
+)
+
+func (l Level) String() string {
+	switch l {
+	case TraceLevel:
+		return "trace"
+	case DebugLevel:
+		return "debug"
+	case InfoLevel:
+		return "info"
+	case WarnLevel:
+		return "warn"
+	case ErrorLevel:
+		return "error"
+	case FatalLevel:
+		return "fatal"
+	case PanicLevel:
+		return "panic"
+	case OffLevel:
+		return "off"
+	default:
+		return "unknown"
+	}
+}
+
+// Fields is the structured context attached to an Entry via WithField or
+// WithFields.
+type Fields map[string]any
+
+// Formatter renders a single log entry to the string a Logger writes out.
+type Formatter interface {
+	Format(level Level, msg string, fields Fields) string
+}
+
+const ansiReset = "\x1b[0m"
+
+func levelColor(level Level) string {
+	switch level {
+	case TraceLevel, DebugLevel:
+		return "\x1b[90m"
+	case InfoLevel:
+		return "\x1b[36m"
+	case WarnLevel:
+		return "\x1b[33m"
+	case ErrorLevel, FatalLevel, PanicLevel:
+		return "\x1b[31m"
+	default:
+		return ""
+	}
+}
+
+// TextFormatter renders entries as "LEVEL message key=value ...", colorized
+// with ANSI codes when Color is set.
+type TextFormatter struct {
+	Color bool
+}
+
+func (f *TextFormatter) Format(level Level, msg string, fields Fields) string {
+	var b strings.Builder
+	if f.Color {
+		b.WriteString(levelColor(level))
+	}
+	b.WriteString(strings.ToUpper(level.String()))
+	if f.Color {
+		b.WriteString(ansiReset)
+	}
+	b.WriteString(" ")
+	b.WriteString(msg)
+	for _, k := range sortedFieldKeys(fields) {
+		fmt.Fprintf(&b, " %s=%v", k, fields[k])
+	}
+	return b.String()
+}
+
+// JSONFormatter renders one JSON object per entry, with "time", "level", and
+// "msg" alongside any Fields.
+type JSONFormatter struct{}
+
+func (f *JSONFormatter) Format(level Level, msg string, fields Fields) string {
+	entry := make(map[string]any, len(fields)+3)
+	for k, v := range fields {
+		entry[k] = v
+	}
+	entry["time"] = time.Now().Format(time.RFC3339)
+	entry["level"] = level.String()
+	entry["msg"] = msg
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return msg
+	}
+	return string(b)
+}
+
+func sortedFieldKeys(fields Fields) []string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func isTTY(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && isatty.IsTerminal(f.Fd())
+}
+
 type Logger struct {
-	base    *log.Logger
-	verbose bool
+	base      *log.Logger
+	level     Level
+	formatter Formatter
 }
 
 func NewLogger() *Logger {
-	l := &Logger{base: log.Default()}
+	l := &Logger{base: log.Default(), level: InfoLevel}
 	l.base.SetFlags(0)
+	l.formatter = &TextFormatter{Color: isTTY(l.base.Writer())}
 	return l
 }
 
@@ -26,12 +161,24 @@ func (l *Logger) Wrap(b *log.Logger) {
 	l.base = b
 }
 
+// SetLevel sets the minimum Level this Logger emits.
+func (l *Logger) SetLevel(level Level) {
+	l.level = level
+}
+
+// SetFormatter sets the Formatter used to render entries.
+func (l *Logger) SetFormatter(f Formatter) {
+	l.formatter = f
+}
+
+// SetVerbose is kept for back-compat; it is equivalent to SetLevel(InfoLevel).
 func (l *Logger) SetVerbose() {
-	l.verbose = true
+	l.SetLevel(InfoLevel)
 }
 
+// SetQuiet is kept for back-compat; it is equivalent to SetLevel(ErrorLevel).
 func (l *Logger) SetQuiet() {
-	l.verbose = false
+	l.SetLevel(ErrorLevel)
 }
 
 // SetOutput sets the output destination for the logger.
@@ -39,85 +186,107 @@ func (l *Logger) SetOutput(w io.Writer) {
 	if l.base != nil {
 		l.base.SetOutput(w)
 	}
+	if tf, ok := l.formatter.(*TextFormatter); ok {
+		tf.Color = isTTY(w)
+	}
 }
 
-// Output writes the output for a logging event. The string s contains
-// the text to print after the prefix specified by the flags of the
-// Logger. A newline is appended if the last character of s is not
-// already a newline. Calldepth is used to recover the PC and is
-// provided for generality, although at the moment on all pre-defined
-// paths it will be 2.
-func (l *Logger) Output(calldepth int, s string) error {
-	if l.base != nil && l.verbose {
-		return l.base.Output(calldepth, s)
+func (l *Logger) entry(level Level, fields Fields, msg string) {
+	if level < l.level && level != FatalLevel && level != PanicLevel {
+		return
+	}
+	if l.base != nil {
+		out := msg
+		if l.formatter != nil {
+			out = l.formatter.Format(level, msg, fields)
+		}
+		l.base.Output(4, out)
+	}
+	switch level {
+	case FatalLevel:
+		os.Exit(1)
+	case PanicLevel:
+		panic(msg)
 	}
-	return nil
 }
 
-// Printf calls l.Output to print to the logger.
-// Arguments are handled in the manner of fmt.Printf.
-func (l *Logger) Printf(format string, v ...any) {
-	if l.base != nil && l.verbose {
-		l.base.Printf(format, v...)
-	}
+// L logs msg, formatted with fmt.Sprint, at level.
+func (l *Logger) L(level Level, v ...any) {
+	l.entry(level, nil, fmt.Sprint(v...))
 }
 
-// Print calls l.Output to print to the logger.
-// Arguments are handled in the manner of fmt.Print.
-func (l *Logger) Print(v ...any) {
-	if l.base != nil && l.verbose {
-		l.base.Print(v...)
-	}
+// Lf logs msg, formatted with fmt.Sprintf, at level.
+func (l *Logger) Lf(level Level, format string, v ...any) {
+	l.entry(level, nil, fmt.Sprintf(format, v...))
 }
 
-// Println calls l.Output to print to the logger.
-// Arguments are handled in the manner of fmt.Println.
-func (l *Logger) Println(v ...any) {
-	if l.base != nil && l.verbose {
-		l.base.Println(v...)
-	}
+// Lln logs msg, formatted with fmt.Sprintln, at level.
+func (l *Logger) Lln(level Level, v ...any) {
+	l.entry(level, nil, fmt.Sprintln(v...))
 }
 
+func (l *Logger) Trace(v ...any)                 { l.L(TraceLevel, v...) }
+func (l *Logger) Tracef(format string, v ...any) { l.Lf(TraceLevel, format, v...) }
+func (l *Logger) Traceln(v ...any)               { l.Lln(TraceLevel, v...) }
+
+func (l *Logger) Debug(v ...any)                 { l.L(DebugLevel, v...) }
+func (l *Logger) Debugf(format string, v ...any) { l.Lf(DebugLevel, format, v...) }
+func (l *Logger) Debugln(v ...any)               { l.Lln(DebugLevel, v...) }
+
+func (l *Logger) Info(v ...any)                 { l.L(InfoLevel, v...) }
+func (l *Logger) Infof(format string, v ...any) { l.Lf(InfoLevel, format, v...) }
+func (l *Logger) Infoln(v ...any)               { l.Lln(InfoLevel, v...) }
+
+func (l *Logger) Warn(v ...any)                 { l.L(WarnLevel, v...) }
+func (l *Logger) Warnf(format string, v ...any) { l.Lf(WarnLevel, format, v...) }
+func (l *Logger) Warnln(v ...any)               { l.Lln(WarnLevel, v...) }
+
+func (l *Logger) Error(v ...any)                 { l.L(ErrorLevel, v...) }
+func (l *Logger) Errorf(format string, v ...any) { l.Lf(ErrorLevel, format, v...) }
+func (l *Logger) Errorln(v ...any)               { l.Lln(ErrorLevel, v...) }
+
+// Printf prints to the logger at Info level.
+// Arguments are handled in the manner of fmt.Printf.
+func (l *Logger) Printf(format string, v ...any) { l.Lf(InfoLevel, format, v...) }
+
+// Print prints to the logger at Info level.
+// Arguments are handled in the manner of fmt.Print.
+func (l *Logger) Print(v ...any) { l.L(InfoLevel, v...) }
+
+// Println prints to the logger at Info level.
+// Arguments are handled in the manner of fmt.Println.
+func (l *Logger) Println(v ...any) { l.Lln(InfoLevel, v...) }
+
 // Fatal is equivalent to l.Print() followed by a call to os.Exit(1).
-func (l *Logger) Fatal(v ...any) {
-	if l.base != nil {
-		l.base.Fatal(v...)
-	}
-}
+func (l *Logger) Fatal(v ...any) { l.L(FatalLevel, v...) }
 
 // Fatalf is equivalent to l.Printf() followed by a call to os.Exit(1).
-func (l *Logger) Fatalf(format string, v ...any) {
-	if l.base != nil {
-		l.base.Fatalf(format, v...)
-	}
-}
+func (l *Logger) Fatalf(format string, v ...any) { l.Lf(FatalLevel, format, v...) }
 
 // Fatalln is equivalent to l.Println() followed by a call to os.Exit(1).
-func (l *Logger) Fatalln(v ...any) {
-	if l.base != nil {
-		l.base.Fatalln(v...)
-	}
-}
+func (l *Logger) Fatalln(v ...any) { l.Lln(FatalLevel, v...) }
 
 // Panic is equivalent to l.Print() followed by a call to panic().
-func (l *Logger) Panic(v ...any) {
-	if l.base != nil {
-		l.base.Panic(v...)
-	}
-}
+func (l *Logger) Panic(v ...any) { l.L(PanicLevel, v...) }
 
 // Panicf is equivalent to l.Printf() followed by a call to panic().
-func (l *Logger) Panicf(format string, v ...any) {
-	if l.base != nil {
-		l.base.Panicf(format, v...)
-	}
-}
+func (l *Logger) Panicf(format string, v ...any) { l.Lf(PanicLevel, format, v...) }
 
 // Panicln is equivalent to l.Println() followed by a call to panic().
-func (l *Logger) Panicln(v ...any) {
+func (l *Logger) Panicln(v ...any) { l.Lln(PanicLevel, v...) }
+
+// Output writes the output for a logging event. The string s contains
+// the text to print after the prefix specified by the flags of the
+// Logger. A newline is appended if the last character of s is not
+// already a newline. Calldepth is used to recover the PC and is passed
+// through to the underlying log.Logger. Output writes s as given,
+// regardless of the Logger's Level and Formatter; the level methods do
+// not go through it.
+func (l *Logger) Output(calldepth int, s string) error {
 	if l.base != nil {
-		l.base.Panicln(v...)
+		return l.base.Output(calldepth, s)
 	}
+	return nil
 }
 
 // Flags returns the output flags for the logger.
@@ -159,3 +328,81 @@ func (l *Logger) Writer() io.Writer {
 	}
 	return nil
 }
+
+// Entry carries structured context, attached via WithField/WithFields,
+// through to a Logger's Formatter.
+type Entry struct {
+	logger *Logger
+	fields Fields
+}
+
+// WithField returns an Entry carrying k=v as structured context.
+func (l *Logger) WithField(k string, v any) *Entry {
+	return &Entry{logger: l, fields: Fields{k: v}}
+}
+
+// WithFields returns an Entry carrying fields as structured context.
+func (l *Logger) WithFields(fields Fields) *Entry {
+	f := make(Fields, len(fields))
+	for k, v := range fields {
+		f[k] = v
+	}
+	return &Entry{logger: l, fields: f}
+}
+
+// WithField returns a copy of e with k=v merged into its fields.
+func (e *Entry) WithField(k string, v any) *Entry {
+	return e.WithFields(Fields{k: v})
+}
+
+// WithFields returns a copy of e with fields merged into its fields.
+func (e *Entry) WithFields(fields Fields) *Entry {
+	f := make(Fields, len(e.fields)+len(fields))
+	for k, v := range e.fields {
+		f[k] = v
+	}
+	for k, v := range fields {
+		f[k] = v
+	}
+	return &Entry{logger: e.logger, fields: f}
+}
+
+func (e *Entry) L(level Level, v ...any) {
+	e.logger.entry(level, e.fields, fmt.Sprint(v...))
+}
+
+func (e *Entry) Lf(level Level, format string, v ...any) {
+	e.logger.entry(level, e.fields, fmt.Sprintf(format, v...))
+}
+
+func (e *Entry) Lln(level Level, v ...any) {
+	e.logger.entry(level, e.fields, fmt.Sprintln(v...))
+}
+
+func (e *Entry) Trace(v ...any)                 { e.L(TraceLevel, v...) }
+func (e *Entry) Tracef(format string, v ...any) { e.Lf(TraceLevel, format, v...) }
+func (e *Entry) Traceln(v ...any)               { e.Lln(TraceLevel, v...) }
+
+func (e *Entry) Debug(v ...any)                 { e.L(DebugLevel, v...) }
+func (e *Entry) Debugf(format string, v ...any) { e.Lf(DebugLevel, format, v...) }
+func (e *Entry) Debugln(v ...any)               { e.Lln(DebugLevel, v...) }
+
+func (e *Entry) Info(v ...any)                 { e.L(InfoLevel, v...) }
+func (e *Entry) Infof(format string, v ...any) { e.Lf(InfoLevel, format, v...) }
+func (e *Entry) Infoln(v ...any)               { e.Lln(InfoLevel, v...) }
+
+func (e *Entry) Warn(v ...any)                 { e.L(WarnLevel, v...) }
+func (e *Entry) Warnf(format string, v ...any) { e.Lf(WarnLevel, format, v...) }
+func (e *Entry) Warnln(v ...any)               { e.Lln(WarnLevel, v...) }
+
+func (e *Entry) Error(v ...any)                 { e.L(ErrorLevel, v...) }
+func (e *Entry) Errorf(format string, v ...any) { e.Lf(ErrorLevel, format, v...) }
+func (e *Entry) Errorln(v ...any)               { e.Lln(ErrorLevel, v...) }
+
+func (e *Entry) Fatal(v ...any)                 { e.L(FatalLevel, v...) }
+func (e *Entry) Fatalf(format string, v ...any) { e.Lf(FatalLevel, format, v...) }
+func (e *Entry) Fatalln(v ...any)               { e.Lln(FatalLevel, v...) }
+
+func (e *Entry) Panic(v ...any)                 { e.L(PanicLevel, v...) }
+func (e *Entry) Panicf(format string, v ...any) { e.Lf(PanicLevel, format, v...) }
+func (e *Entry) Panicln(v ...any)               { e.Lln(PanicLevel, v...) }
@@ -0,0 +1,14 @@
+package gocli
+
+import "testing"
+
+func TestPanicBypassesLevelGate(t *testing.T) {
+	l := &Logger{level: Level(100)}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected Panic to panic even though the configured level is above PanicLevel")
+		}
+	}()
+	l.Panic("boom")
+}